@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ChangeOp identifies the kind of mutation a Change recorded.
+type ChangeOp string
+
+const (
+	ChangeCreate ChangeOp = "create"
+	ChangeUpdate ChangeOp = "update"
+	ChangeDelete ChangeOp = "delete"
+)
+
+// Change is a single recorded mutation of an entity, e.g. a User saved
+// through UserRepository.Save.
+type Change struct {
+	ID         int64
+	EntityType string
+	EntityID   any
+	Op         ChangeOp
+	Before     any
+	After      any
+	Actor      string
+	At         time.Time
+}
+
+// ChangeRepository records and queries the audit trail for entity mutations.
+// A nil ChangeRepository passed to NewUserRepository disables auditing.
+type ChangeRepository interface {
+	// Record persists change, assigning change.ID if it is zero.
+	Record(ctx context.Context, change Change) error
+	// ListChanges returns every recorded change for entityID, oldest first.
+	ListChanges(ctx context.Context, entityID any) ([]Change, error)
+}
+
+// entityKey normalizes an EntityID for comparison. Durable ChangeRepository
+// implementations round-trip EntityID through encoding/json, which turns a
+// numeric key like UserID into a float64 on the way back out; comparing the
+// normalized string form instead keeps FindByID-style keys equal across
+// that round trip.
+func entityKey(id any) string {
+	if f, ok := id.(float64); ok {
+		// fmt.Sprint would print large whole numbers as e.g. "1e+06".
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(id)
+}
+
+type actorContextKey struct{}
+
+// WithActor attaches the identity of the caller performing a mutation to
+// ctx, so HTTP/gRPC handlers can set it once instead of threading an actor
+// parameter through every repository call.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by WithActor, or "" if none was set.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorContextKey{}).(string)
+	return actor
+}
+
+// recordChange diffs before/after and hands the result to recorder, shared
+// by every Repository implementation so the Change shape only has one
+// place to drift. A nil recorder disables auditing. It copies before/after
+// so later mutations of the live *User don't retroactively change what was
+// recorded.
+func recordChange(ctx context.Context, recorder ChangeRepository, logger Logger, op ChangeOp, entityType string, id UserID, before, after *User) {
+	if recorder == nil {
+		return
+	}
+
+	var beforeCopy, afterCopy *User
+	if before != nil {
+		b := *before
+		beforeCopy = &b
+	}
+	if after != nil {
+		a := *after
+		afterCopy = &a
+	}
+
+	change := Change{
+		EntityType: entityType,
+		EntityID:   id,
+		Op:         op,
+		Before:     beforeCopy,
+		After:      afterCopy,
+		Actor:      ActorFromContext(ctx),
+		At:         time.Now(),
+	}
+	if err := recorder.Record(ctx, change); err != nil {
+		logger.Error("failed to record change", "error", err)
+	}
+}
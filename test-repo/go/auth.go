@@ -0,0 +1,325 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Auth-specific sentinel errors, following the same pattern as ErrNotFound.
+var (
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrAccountNotActive   = errors.New("account is not active")
+	ErrSessionExpired     = errors.New("session expired")
+)
+
+// Credential is a User's password, stored separately from User itself so
+// password material never travels with the rest of the profile.
+type Credential struct {
+	UserID       UserID
+	PasswordHash []byte
+	Algo         string
+	UpdatedAt    time.Time
+}
+
+// CredentialRepository stores and retrieves a User's password Credential.
+type CredentialRepository interface {
+	FindByUserID(ctx context.Context, userID UserID) (*Credential, error)
+	Save(ctx context.Context, cred *Credential) error
+}
+
+// Session is an issued, opaque login session for a User.
+type Session struct {
+	ID           string
+	UserID       UserID
+	RefreshToken string
+	IssuedAt     time.Time
+	ExpiresAt    time.Time
+}
+
+// SessionRepository stores issued Sessions, keyed by both their ID and
+// refresh token so Middleware and Refresh can look them up directly.
+type SessionRepository interface {
+	FindByID(ctx context.Context, id string) (*Session, error)
+	FindByRefreshToken(ctx context.Context, token string) (*Session, error)
+	Save(ctx context.Context, session *Session) error
+	Delete(ctx context.Context, id string) error
+}
+
+// ArgonParams configures the cost of the argon2id password hash.
+type ArgonParams struct {
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgonParams returns conservative, interactive-login cost
+// parameters: the memory, pass count, salt and key sizes of RFC 9106's
+// 64 MiB argon2id option, with 2 lanes of parallelism instead of its 4.
+func DefaultArgonParams() ArgonParams {
+	return ArgonParams{
+		Memory:      64 * 1024,
+		Iterations:  3,
+		Parallelism: 2,
+		SaltLength:  16,
+		KeyLength:   32,
+	}
+}
+
+// AuthService registers users, authenticates password credentials, and
+// issues/refreshes/revokes login Sessions.
+type AuthService struct {
+	users       Repository
+	credentials CredentialRepository
+	sessions    SessionRepository
+	params      ArgonParams
+	sessionTTL  time.Duration
+	logger      Logger
+}
+
+// NewAuthService builds an AuthService with DefaultArgonParams; use
+// SetArgonParams to tune the hashing cost.
+func NewAuthService(users Repository, credentials CredentialRepository, sessions SessionRepository, sessionTTL time.Duration, logger Logger) *AuthService {
+	return &AuthService{
+		users:       users,
+		credentials: credentials,
+		sessions:    sessions,
+		params:      DefaultArgonParams(),
+		sessionTTL:  sessionTTL,
+		logger:      logger,
+	}
+}
+
+// SetArgonParams overrides the argon2id cost parameters used for
+// subsequently hashed passwords.
+func (s *AuthService) SetArgonParams(params ArgonParams) {
+	s.params = params
+}
+
+// Register creates a new active User and stores a Credential for password.
+// Hashing happens before the User is persisted, so a hashing failure can't
+// leave a row behind; if the Credential still fails to save, the User is
+// rolled back rather than left active with no way to authenticate.
+func (s *AuthService) Register(ctx context.Context, name, email, password string) (*User, error) {
+	hash, err := hashPassword(password, s.params)
+	if err != nil {
+		return nil, fmt.Errorf("auth: hash password: %w", err)
+	}
+
+	user := NewUser(name, email)
+	if err := s.users.Save(ctx, user); err != nil {
+		return nil, err
+	}
+
+	cred := &Credential{
+		UserID:       user.ID,
+		PasswordHash: hash,
+		Algo:         "argon2id",
+		UpdatedAt:    time.Now(),
+	}
+	if err := s.credentials.Save(ctx, cred); err != nil {
+		if delErr := s.users.Delete(ctx, user.ID); delErr != nil {
+			s.logger.Error("failed to roll back user after credential save failure", "user_id", user.ID, "error", delErr)
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Authenticate verifies email/password and issues a new Session. It
+// rejects StatusInactive and StatusPending users.
+func (s *AuthService) Authenticate(ctx context.Context, email, password string) (*Session, error) {
+	user, err := s.users.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	if user.Status != StatusActive {
+		return nil, ErrAccountNotActive
+	}
+
+	cred, err := s.credentials.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	ok, err := verifyPassword(password, cred.PasswordHash)
+	if err != nil {
+		return nil, fmt.Errorf("auth: verify password: %w", err)
+	}
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+
+	return s.issueSession(ctx, user.ID)
+}
+
+// Refresh extends a Session's expiry (sliding expiration) if refreshToken
+// is still valid.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*Session, error) {
+	session, err := s.sessions.FindByRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return nil, ErrSessionExpired
+	}
+	if time.Now().After(session.ExpiresAt) {
+		_ = s.sessions.Delete(ctx, session.ID)
+		return nil, ErrSessionExpired
+	}
+
+	session.ExpiresAt = time.Now().Add(s.sessionTTL)
+	if err := s.sessions.Save(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Revoke deletes a Session, logging the user out.
+func (s *AuthService) Revoke(ctx context.Context, sessionID string) error {
+	return s.sessions.Delete(ctx, sessionID)
+}
+
+func (s *AuthService) issueSession(ctx context.Context, userID UserID) (*Session, error) {
+	id, err := newOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("auth: generate session token: %w", err)
+	}
+	refreshToken, err := newOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("auth: generate refresh token: %w", err)
+	}
+
+	now := time.Now()
+	session := &Session{
+		ID:           id,
+		UserID:       userID,
+		RefreshToken: refreshToken,
+		IssuedAt:     now,
+		ExpiresAt:    now.Add(s.sessionTTL),
+	}
+	if err := s.sessions.Save(ctx, session); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("session issued", "user_id", userID)
+	return session, nil
+}
+
+type userContextKey struct{}
+
+// WithUser attaches user to ctx, as done by AuthService.Middleware.
+func WithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext returns the *User attached by AuthService.Middleware, if any.
+func UserFromContext(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(userContextKey{}).(*User)
+	return user, ok
+}
+
+// Middleware decodes a bearer session token from each request, resolves
+// its User, and injects it into the request's context so handlers can call
+// UserFromContext instead of looking the user up themselves. Requests
+// without a valid session are passed through unauthenticated.
+func (s *AuthService) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := bearerToken(r.Header.Get("Authorization"))
+			if token == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			session, err := s.sessions.FindByID(r.Context(), token)
+			if err != nil || time.Now().After(session.ExpiresAt) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			user, err := s.users.FindByID(r.Context(), session.UserID)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithUser(r.Context(), user)))
+		})
+	}
+}
+
+func bearerToken(header string) string {
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(header, prefix)
+}
+
+// hashPassword derives an argon2id hash and encodes it alongside the salt
+// and cost parameters used, so verifyPassword can recompute it later even
+// if DefaultArgonParams changes.
+func hashPassword(password string, p ArgonParams) ([]byte, error) {
+	salt := make([]byte, p.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	hash := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+
+	encoded := fmt.Sprintf("%d$%d$%d$%s$%s",
+		p.Memory, p.Iterations, p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(hash),
+	)
+	return []byte(encoded), nil
+}
+
+func verifyPassword(password string, encoded []byte) (bool, error) {
+	parts := strings.Split(string(encoded), "$")
+	if len(parts) != 5 {
+		return false, errors.New("auth: malformed password hash")
+	}
+
+	memory, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return false, err
+	}
+	iterations, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return false, err
+	}
+	parallelism, err := strconv.ParseUint(parts[2], 10, 8)
+	if err != nil {
+		return false, err
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false, err
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, err
+	}
+
+	got := argon2.IDKey([]byte(password), salt, uint32(iterations), uint32(memory), uint8(parallelism), uint32(len(want)))
+	return subtle.ConstantTimeCompare(got, want) == 1, nil
+}
+
+func newOpaqueToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
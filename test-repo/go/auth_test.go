@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// flakyCredentialRepository fails its first N Save calls, so tests can
+// exercise AuthService.Register's rollback path and then confirm a
+// subsequent attempt succeeds.
+type flakyCredentialRepository struct {
+	inner    CredentialRepository
+	failures int
+}
+
+func (f *flakyCredentialRepository) FindByUserID(ctx context.Context, userID UserID) (*Credential, error) {
+	return f.inner.FindByUserID(ctx, userID)
+}
+
+func (f *flakyCredentialRepository) Save(ctx context.Context, cred *Credential) error {
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("boom")
+	}
+	return f.inner.Save(ctx, cred)
+}
+
+func newTestAuthService(credentials CredentialRepository) (*AuthService, *UserRepository) {
+	users := NewUserRepository(&SimpleLogger{}, nil)
+	svc := NewAuthService(users, credentials, NewInMemorySessionRepository(), time.Hour, &SimpleLogger{})
+	return svc, users
+}
+
+func TestAuthService_RegisterAndAuthenticate(t *testing.T) {
+	svc, _ := newTestAuthService(NewInMemoryCredentialRepository())
+	ctx := context.Background()
+
+	if _, err := svc.Register(ctx, "Alice", "alice@example.com", "hunter2"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	session, err := svc.Authenticate(ctx, "alice@example.com", "hunter2")
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if session.ID == "" {
+		t.Error("expected a non-empty session ID")
+	}
+
+	if _, err := svc.Authenticate(ctx, "alice@example.com", "wrong-password"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("got err %v, want ErrInvalidCredentials", err)
+	}
+}
+
+func TestAuthService_Register_RollsBackUserOnCredentialFailure(t *testing.T) {
+	credentials := &flakyCredentialRepository{inner: NewInMemoryCredentialRepository(), failures: 1}
+	svc, users := newTestAuthService(credentials)
+	ctx := context.Background()
+
+	if _, err := svc.Register(ctx, "Alice", "alice@example.com", "hunter2"); err == nil {
+		t.Fatal("expected Register to fail when the credential save fails")
+	}
+
+	all, err := users.List(ctx, ListFilter{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(all) != 0 {
+		t.Fatalf("got %d users after rollback, want 0", len(all))
+	}
+
+	// Retrying under the same email should succeed now that the failed
+	// attempt's user row was rolled back, rather than tripping Unique.
+	if _, err := svc.Register(ctx, "Alice", "alice@example.com", "hunter2"); err != nil {
+		t.Fatalf("Register after rollback: %v", err)
+	}
+}
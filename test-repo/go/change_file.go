@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileChangeRepository appends each Change as a JSON line to a file,
+// giving a durable, append-only audit trail that survives a restart.
+type FileChangeRepository struct {
+	mu     sync.RWMutex
+	path   string
+	nextID int64
+}
+
+// NewFileChangeRepository prepares to record changes to the JSONL file at
+// path; the file is created by the first Record if it does not exist yet.
+// It replays any existing file once to seed the next change ID.
+func NewFileChangeRepository(path string) (*FileChangeRepository, error) {
+	c := &FileChangeRepository{path: path, nextID: 1}
+
+	changes, err := c.readAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range changes {
+		if change.ID >= c.nextID {
+			c.nextID = change.ID + 1
+		}
+	}
+	return c, nil
+}
+
+func (c *FileChangeRepository) Record(ctx context.Context, change Change) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if change.ID == 0 {
+		change.ID = c.nextID
+		c.nextID++
+	}
+
+	f, err := os.OpenFile(c.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("change_file: open %s: %w", c.path, err)
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(change)
+	if err != nil {
+		return fmt.Errorf("change_file: marshal change: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("change_file: write %s: %w", c.path, err)
+	}
+	return nil
+}
+
+func (c *FileChangeRepository) ListChanges(ctx context.Context, entityID any) ([]Change, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	all, err := c.readAll()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Change, 0)
+	key := entityKey(entityID)
+	for _, change := range all {
+		if entityKey(change.EntityID) == key {
+			result = append(result, change)
+		}
+	}
+	return result, nil
+}
+
+func (c *FileChangeRepository) readAll() ([]Change, error) {
+	f, err := os.Open(c.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("change_file: open %s: %w", c.path, err)
+	}
+	defer f.Close()
+
+	changes := make([]Change, 0)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var change Change
+		if err := json.Unmarshal(scanner.Bytes(), &change); err != nil {
+			return nil, fmt.Errorf("change_file: decode line: %w", err)
+		}
+		changes = append(changes, change)
+	}
+	return changes, scanner.Err()
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileChangeRepository_RecordAndListChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "changes.jsonl")
+	repo, err := NewFileChangeRepository(path)
+	if err != nil {
+		t.Fatalf("NewFileChangeRepository: %v", err)
+	}
+
+	ctx := context.Background()
+	userID := UserID(42)
+
+	if err := repo.Record(ctx, Change{
+		EntityType: "User",
+		EntityID:   userID,
+		Op:         ChangeCreate,
+	}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	changes, err := repo.ListChanges(ctx, userID)
+	if err != nil {
+		t.Fatalf("ListChanges: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("got %d changes, want 1", len(changes))
+	}
+	if changes[0].Op != ChangeCreate {
+		t.Errorf("got op %q, want %q", changes[0].Op, ChangeCreate)
+	}
+}
+
+func TestFileChangeRepository_ListChanges_AfterReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "changes.jsonl")
+	ctx := context.Background()
+	userID := UserID(7)
+
+	repo, err := NewFileChangeRepository(path)
+	if err != nil {
+		t.Fatalf("NewFileChangeRepository: %v", err)
+	}
+	if err := repo.Record(ctx, Change{EntityType: "User", EntityID: userID, Op: ChangeUpdate}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	// Re-open so ListChanges reads the change back through the JSON round
+	// trip, not just the in-process value Record was given.
+	reopened, err := NewFileChangeRepository(path)
+	if err != nil {
+		t.Fatalf("NewFileChangeRepository (reopen): %v", err)
+	}
+
+	changes, err := reopened.ListChanges(ctx, userID)
+	if err != nil {
+		t.Fatalf("ListChanges: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("got %d changes, want 1", len(changes))
+	}
+}
+
+func TestUserRepository_SaveRecordsChangeInFileBackedLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "changes.jsonl")
+	recorder, err := NewFileChangeRepository(path)
+	if err != nil {
+		t.Fatalf("NewFileChangeRepository: %v", err)
+	}
+
+	repo := NewUserRepository(&SimpleLogger{}, recorder)
+	ctx := context.Background()
+
+	user := NewUser("Alice", "alice@example.com")
+	if err := repo.Save(ctx, user); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	changes, err := repo.ListChanges(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("ListChanges: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("got %d changes, want 1", len(changes))
+	}
+	if changes[0].Op != ChangeCreate {
+		t.Errorf("got op %q, want %q", changes[0].Op, ChangeCreate)
+	}
+}
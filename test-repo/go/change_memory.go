@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// InMemoryChangeRepository keeps the audit trail in a slice guarded by a
+// sync.RWMutex. Changes do not survive a restart.
+type InMemoryChangeRepository struct {
+	mu      sync.RWMutex
+	changes []Change
+	nextID  int64
+}
+
+// NewInMemoryChangeRepository builds an empty InMemoryChangeRepository.
+func NewInMemoryChangeRepository() *InMemoryChangeRepository {
+	return &InMemoryChangeRepository{nextID: 1}
+}
+
+func (c *InMemoryChangeRepository) Record(ctx context.Context, change Change) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if change.ID == 0 {
+		change.ID = c.nextID
+		c.nextID++
+	}
+	c.changes = append(c.changes, change)
+	return nil
+}
+
+func (c *InMemoryChangeRepository) ListChanges(ctx context.Context, entityID any) ([]Change, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make([]Change, 0)
+	key := entityKey(entityID)
+	for _, change := range c.changes {
+		if entityKey(change.EntityID) == key {
+			result = append(result, change)
+		}
+	}
+	return result, nil
+}
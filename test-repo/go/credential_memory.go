@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// InMemoryCredentialRepository keeps Credentials in a map guarded by a
+// sync.RWMutex. Credentials do not survive a restart.
+type InMemoryCredentialRepository struct {
+	mu    sync.RWMutex
+	items map[UserID]*Credential
+}
+
+// NewInMemoryCredentialRepository builds an empty InMemoryCredentialRepository.
+func NewInMemoryCredentialRepository() *InMemoryCredentialRepository {
+	return &InMemoryCredentialRepository{items: make(map[UserID]*Credential)}
+}
+
+func (c *InMemoryCredentialRepository) FindByUserID(ctx context.Context, userID UserID) (*Credential, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cred, ok := c.items[userID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return cred, nil
+}
+
+func (c *InMemoryCredentialRepository) Save(ctx context.Context, cred *Credential) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items[cred.UserID] = cred
+	return nil
+}
@@ -0,0 +1,141 @@
+// Package repository provides a generic, reusable CRUD subsystem so new
+// aggregates (users, orders, sessions, ...) can be stored without copy-pasting
+// map+RWMutex boilerplate for each one.
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrNotFound is returned when a lookup does not match any stored entity.
+var ErrNotFound = errors.New("repository: entity not found")
+
+// ErrDuplicate is returned when Insert is called with a key that already exists.
+var ErrDuplicate = errors.New("repository: entity already exists")
+
+// QueryFunc reports whether an entity matches a caller-defined predicate.
+// A nil QueryFunc matches everything.
+type QueryFunc[T any] func(T) bool
+
+// KeyExtractor derives the storage key for an entity, so aggregates that
+// manage their own identity (e.g. an auto-incrementing User.ID) can plug
+// into a Repository without it knowing the key field's name.
+type KeyExtractor[T any, K comparable] func(T) K
+
+// Repository is the generic CRUD contract implemented by every storage
+// backend in this subsystem.
+type Repository[T any, K comparable] interface {
+	// Find returns every stored entity matching query.
+	Find(query QueryFunc[T]) ([]T, error)
+	// FindByID returns the entity stored under id, or ErrNotFound.
+	FindByID(ctx context.Context, id K) (T, error)
+	// Insert stores entity under id. It returns ErrDuplicate if id is taken.
+	Insert(ctx context.Context, id K, entity T) error
+	// Update overwrites the entity stored under id, or returns ErrNotFound.
+	Update(ctx context.Context, id K, entity T) error
+	// Delete removes the entity stored under id, or returns ErrNotFound.
+	Delete(ctx context.Context, id K) error
+	// Exec applies mut to every entity matching match, persisting the
+	// result, and returns the number of entities it touched.
+	Exec(ctx context.Context, match QueryFunc[T], mut func(*T) error) (int, error)
+}
+
+// InMemory is the default Repository implementation, backed by a map
+// guarded by a sync.RWMutex.
+type InMemory[T any, K comparable] struct {
+	mu    sync.RWMutex
+	items map[K]T
+	keyOf KeyExtractor[T, K]
+}
+
+// NewInMemory builds an empty InMemory repository. keyOf lets callers derive
+// an entity's key when they only have the entity, not the key (see KeyOf).
+func NewInMemory[T any, K comparable](keyOf KeyExtractor[T, K]) *InMemory[T, K] {
+	return &InMemory[T, K]{
+		items: make(map[K]T),
+		keyOf: keyOf,
+	}
+}
+
+// KeyOf derives id for entity using the configured KeyExtractor.
+func (r *InMemory[T, K]) KeyOf(entity T) K {
+	return r.keyOf(entity)
+}
+
+func (r *InMemory[T, K]) Find(query QueryFunc[T]) ([]T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]T, 0, len(r.items))
+	for _, item := range r.items {
+		if query == nil || query(item) {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+func (r *InMemory[T, K]) FindByID(ctx context.Context, id K) (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	item, ok := r.items[id]
+	if !ok {
+		var zero T
+		return zero, ErrNotFound
+	}
+	return item, nil
+}
+
+func (r *InMemory[T, K]) Insert(ctx context.Context, id K, entity T) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.items[id]; exists {
+		return ErrDuplicate
+	}
+	r.items[id] = entity
+	return nil
+}
+
+func (r *InMemory[T, K]) Update(ctx context.Context, id K, entity T) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.items[id]; !exists {
+		return ErrNotFound
+	}
+	r.items[id] = entity
+	return nil
+}
+
+func (r *InMemory[T, K]) Delete(ctx context.Context, id K) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.items[id]; !exists {
+		return ErrNotFound
+	}
+	delete(r.items, id)
+	return nil
+}
+
+func (r *InMemory[T, K]) Exec(ctx context.Context, match QueryFunc[T], mut func(*T) error) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for id, item := range r.items {
+		if match != nil && !match(item) {
+			continue
+		}
+		if err := mut(&item); err != nil {
+			return count, err
+		}
+		r.items[id] = item
+		count++
+	}
+	return count, nil
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type item struct {
+	id    int
+	value string
+}
+
+func newItemRepo() *InMemory[item, int] {
+	return NewInMemory[item, int](func(i item) int { return i.id })
+}
+
+func TestInMemory_InsertAndFindByID(t *testing.T) {
+	repo := newItemRepo()
+	ctx := context.Background()
+
+	if err := repo.Insert(ctx, 1, item{id: 1, value: "a"}); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+
+	got, err := repo.FindByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if got.value != "a" {
+		t.Errorf("got value %q, want %q", got.value, "a")
+	}
+}
+
+func TestInMemory_Insert_DuplicateKeyFails(t *testing.T) {
+	repo := newItemRepo()
+	ctx := context.Background()
+
+	if err := repo.Insert(ctx, 1, item{id: 1, value: "a"}); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	if err := repo.Insert(ctx, 1, item{id: 1, value: "b"}); !errors.Is(err, ErrDuplicate) {
+		t.Errorf("got err %v, want ErrDuplicate", err)
+	}
+}
+
+func TestInMemory_FindByID_NotFound(t *testing.T) {
+	repo := newItemRepo()
+
+	if _, err := repo.FindByID(context.Background(), 99); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got err %v, want ErrNotFound", err)
+	}
+}
+
+func TestInMemory_Update(t *testing.T) {
+	repo := newItemRepo()
+	ctx := context.Background()
+	_ = repo.Insert(ctx, 1, item{id: 1, value: "a"})
+
+	if err := repo.Update(ctx, 1, item{id: 1, value: "b"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, _ := repo.FindByID(ctx, 1)
+	if got.value != "b" {
+		t.Errorf("got value %q, want %q", got.value, "b")
+	}
+}
+
+func TestInMemory_Update_NotFound(t *testing.T) {
+	repo := newItemRepo()
+
+	if err := repo.Update(context.Background(), 1, item{id: 1, value: "a"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got err %v, want ErrNotFound", err)
+	}
+}
+
+func TestInMemory_Delete(t *testing.T) {
+	repo := newItemRepo()
+	ctx := context.Background()
+	_ = repo.Insert(ctx, 1, item{id: 1, value: "a"})
+
+	if err := repo.Delete(ctx, 1); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got err %v, want ErrNotFound", err)
+	}
+}
+
+func TestInMemory_Delete_NotFound(t *testing.T) {
+	repo := newItemRepo()
+
+	if err := repo.Delete(context.Background(), 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got err %v, want ErrNotFound", err)
+	}
+}
+
+func TestInMemory_Find(t *testing.T) {
+	repo := newItemRepo()
+	ctx := context.Background()
+	_ = repo.Insert(ctx, 1, item{id: 1, value: "a"})
+	_ = repo.Insert(ctx, 2, item{id: 2, value: "b"})
+
+	all, err := repo.Find(nil)
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("got %d items, want 2", len(all))
+	}
+
+	matched, err := repo.Find(func(i item) bool { return i.value == "b" })
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	if len(matched) != 1 || matched[0].id != 2 {
+		t.Fatalf("got %v, want [item{id:2}]", matched)
+	}
+}
+
+func TestInMemory_Exec(t *testing.T) {
+	repo := newItemRepo()
+	ctx := context.Background()
+	_ = repo.Insert(ctx, 1, item{id: 1, value: "a"})
+	_ = repo.Insert(ctx, 2, item{id: 2, value: "b"})
+
+	n, err := repo.Exec(ctx, func(i item) bool { return i.id == 1 }, func(i *item) error {
+		i.value = "changed"
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Exec: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("got %d touched, want 1", n)
+	}
+
+	got, _ := repo.FindByID(ctx, 1)
+	if got.value != "changed" {
+		t.Errorf("got value %q, want %q", got.value, "changed")
+	}
+	untouched, _ := repo.FindByID(ctx, 2)
+	if untouched.value != "b" {
+		t.Errorf("Exec touched item 2, want it untouched")
+	}
+}
+
+func TestInMemory_Exec_StopsOnError(t *testing.T) {
+	repo := newItemRepo()
+	ctx := context.Background()
+	_ = repo.Insert(ctx, 1, item{id: 1, value: "a"})
+
+	boom := errors.New("boom")
+	n, err := repo.Exec(ctx, nil, func(i *item) error { return boom })
+	if !errors.Is(err, boom) {
+		t.Errorf("got err %v, want boom", err)
+	}
+	if n != 0 {
+		t.Errorf("got %d touched, want 0", n)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"sync"
 	"time"
+
+	"github.com/Chillbruhhh/Agentic-Coding-Memory/go/repository"
 )
 
 // Type definitions
@@ -31,9 +33,15 @@ var (
 // Interface definitions
 type Repository interface {
 	FindByID(ctx context.Context, id UserID) (*User, error)
+	// FindByEmail looks up the user with the given email, or ErrNotFound.
+	// It exists as a targeted lookup so callers like Unique don't have to
+	// page through List just to check one value.
+	FindByEmail(ctx context.Context, email string) (*User, error)
 	Save(ctx context.Context, user *User) error
 	Delete(ctx context.Context, id UserID) error
-	List(ctx context.Context) ([]*User, error)
+	// List returns users matching filter. A zero-value ListFilter returns
+	// every user, unpaged.
+	List(ctx context.Context, filter ListFilter) ([]*User, error)
 }
 
 type Validator interface {
@@ -42,19 +50,26 @@ type Validator interface {
 
 // Struct definitions
 type User struct {
-	ID        UserID    `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	Status    Status    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	// bson tags mirror the json tags so the mongo driver can use the same
+	// field names without a separate mapping table.
+	ID        UserID    `json:"id" bson:"id"`
+	Name      string    `json:"name" bson:"name"`
+	Email     string    `json:"email" bson:"email"`
+	Status    Status    `json:"status" bson:"status"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
 }
 
+// UserRepository is a thin, User-flavored facade over the generic
+// repository.Repository[*User, UserID] subsystem. It keeps the
+// auto-incrementing ID behavior callers already depend on while delegating
+// storage to whichever repository.Repository implementation it's built with.
 type UserRepository struct {
-	mu      sync.RWMutex
-	users   map[UserID]*User
-	nextID  UserID
-	logger  Logger
+	mu       sync.Mutex // guards nextID only; storage has its own locking
+	repo     repository.Repository[*User, UserID]
+	nextID   UserID
+	logger   Logger
+	recorder ChangeRepository // nil disables auditing
 }
 
 type Logger interface {
@@ -96,86 +111,146 @@ func NewUser(name, email string) *User {
 }
 
 // Method implementations for UserRepository
-func NewUserRepository(logger Logger) *UserRepository {
+//
+// recorder receives a Change for every Save, Delete, and UpdateStatus call;
+// pass nil to disable auditing.
+func NewUserRepository(logger Logger, recorder ChangeRepository) *UserRepository {
+	keyOf := func(u *User) UserID { return u.ID }
 	return &UserRepository{
-		users:  make(map[UserID]*User),
-		nextID: 1,
-		logger: logger,
+		repo:     repository.NewInMemory[*User, UserID](keyOf),
+		nextID:   1,
+		logger:   logger,
+		recorder: recorder,
 	}
 }
 
 func (r *UserRepository) FindByID(ctx context.Context, id UserID) (*User, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-	
-	user, exists := r.users[id]
-	if !exists {
+	user, err := r.repo.FindByID(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
 		return nil, ErrNotFound
 	}
-	
-	return user, nil
+	return user, err
+}
+
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*User, error) {
+	users, err := r.repo.Find(func(u *User) bool { return u.Email == email })
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, ErrNotFound
+	}
+	return users[0], nil
 }
 
 func (r *UserRepository) Save(ctx context.Context, user *User) error {
-	if err := user.Validate(); err != nil {
+	if err := Validate(user, userSaveRules(ctx, r, user.ID)...); err != nil {
 		return err
 	}
-	
+
 	r.mu.Lock()
-	defer r.mu.Unlock()
-	
-	if user.ID == 0 {
+	isNew := user.ID == 0
+	if isNew {
 		user.ID = r.nextID
 		r.nextID++
 		user.CreatedAt = time.Now()
 	}
-	
+	r.mu.Unlock()
+
 	user.UpdatedAt = time.Now()
-	r.users[user.ID] = user
-	
+
+	var before *User
+	if !isNew {
+		// Best-effort: if the lookup fails we still save, just without a diff.
+		before, _ = r.repo.FindByID(ctx, user.ID)
+	}
+
+	var err error
+	if isNew {
+		err = r.repo.Insert(ctx, user.ID, user)
+	} else {
+		err = r.repo.Update(ctx, user.ID, user)
+	}
+	if err != nil {
+		return err
+	}
+
+	op := ChangeUpdate
+	if isNew {
+		op = ChangeCreate
+	}
+	recordChange(ctx, r.recorder, r.logger, op, "User", user.ID, before, user)
+
 	r.logger.Info("User saved", "id", user.ID, "name", user.Name)
 	return nil
 }
 
 func (r *UserRepository) Delete(ctx context.Context, id UserID) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	
-	if _, exists := r.users[id]; !exists {
-		return ErrNotFound
+	before, _ := r.repo.FindByID(ctx, id)
+
+	if err := r.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrNotFound
+		}
+		return err
 	}
-	
-	delete(r.users, id)
+
+	recordChange(ctx, r.recorder, r.logger, ChangeDelete, "User", id, before, nil)
+
 	r.logger.Info("User deleted", "id", id)
 	return nil
 }
 
-func (r *UserRepository) List(ctx context.Context) ([]*User, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-	
-	users := make([]*User, 0, len(r.users))
-	for _, user := range r.users {
-		users = append(users, user)
+// UpdateStatus transitions the user identified by id to status and saves
+// the result, so the change is captured in the audit trail like any other
+// Save.
+func (r *UserRepository) UpdateStatus(ctx context.Context, id UserID, status Status) (*User, error) {
+	user, err := r.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
-	
-	return users, nil
+
+	user.UpdateStatus(status)
+	if err := r.Save(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
 }
 
-func (r *UserRepository) GetActiveUsers(ctx context.Context) ([]*User, error) {
-	allUsers, err := r.List(ctx)
+// ListChanges returns the audit trail recorded for entityID, or an empty
+// slice if auditing is disabled.
+func (r *UserRepository) ListChanges(ctx context.Context, entityID UserID) ([]Change, error) {
+	if r.recorder == nil {
+		return []Change{}, nil
+	}
+	return r.recorder.ListChanges(ctx, entityID)
+}
+
+func (r *UserRepository) List(ctx context.Context, filter ListFilter) ([]*User, error) {
+	var query repository.QueryFunc[*User]
+	if filter.Status != "" {
+		query = func(u *User) bool { return u.Status == filter.Status }
+	}
+
+	users, err := r.repo.Find(query)
 	if err != nil {
 		return nil, err
 	}
-	
-	activeUsers := make([]*User, 0)
-	for _, user := range allUsers {
-		if user.IsActive() {
-			activeUsers = append(activeUsers, user)
+
+	if filter.Offset > 0 {
+		if filter.Offset >= len(users) {
+			return []*User{}, nil
 		}
+		users = users[filter.Offset:]
 	}
-	
-	return activeUsers, nil
+	if filter.Limit > 0 && filter.Limit < len(users) {
+		users = users[:filter.Limit]
+	}
+	return users, nil
+}
+
+func (r *UserRepository) GetActiveUsers(ctx context.Context) ([]*User, error) {
+	return r.repo.Find(func(u *User) bool { return u.IsActive() })
 }
 
 // SimpleLogger implementation
@@ -187,20 +262,6 @@ func (l *SimpleLogger) Error(msg string, args ...interface{}) {
 	fmt.Printf("[ERROR] %s %v\n", msg, args)
 }
 
-// Utility functions
-func ValidateEmail(email string) bool {
-	return len(email) > 0 && contains(email, "@")
-}
-
-func contains(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
-	}
-	return false
-}
-
 // Generic function (Go 1.18+)
 func Filter[T any](items []T, predicate func(T) bool) []T {
 	result := make([]T, 0)
@@ -223,7 +284,7 @@ func Map[T, U any](items []T, mapper func(T) U) []U {
 // Main function
 func main() {
 	logger := &SimpleLogger{}
-	repo := NewUserRepository(logger)
+	repo := NewUserRepository(logger, NewInMemoryChangeRepository())
 	ctx := context.Background()
 	
 	// Create users
@@ -234,7 +295,7 @@ func main() {
 	repo.Save(ctx, user2)
 	
 	// List users
-	users, _ := repo.List(ctx)
+	users, _ := repo.List(ctx, ListFilter{})
 	for _, user := range users {
 		fmt.Printf("User: %s (%s)\n", user.Name, user.Email)
 	}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// InMemorySessionRepository keeps Sessions in maps guarded by a
+// sync.RWMutex, indexed by both ID and refresh token. Sessions do not
+// survive a restart.
+type InMemorySessionRepository struct {
+	mu             sync.RWMutex
+	byID           map[string]*Session
+	byRefreshToken map[string]*Session
+}
+
+// NewInMemorySessionRepository builds an empty InMemorySessionRepository.
+func NewInMemorySessionRepository() *InMemorySessionRepository {
+	return &InMemorySessionRepository{
+		byID:           make(map[string]*Session),
+		byRefreshToken: make(map[string]*Session),
+	}
+}
+
+func (s *InMemorySessionRepository) FindByID(ctx context.Context, id string) (*Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.byID[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return session, nil
+}
+
+func (s *InMemorySessionRepository) FindByRefreshToken(ctx context.Context, token string) (*Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.byRefreshToken[token]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return session, nil
+}
+
+func (s *InMemorySessionRepository) Save(ctx context.Context, session *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.byID[session.ID] = session
+	s.byRefreshToken[session.RefreshToken] = session
+	return nil
+}
+
+func (s *InMemorySessionRepository) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.byID[id]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(s.byID, id)
+	delete(s.byRefreshToken, session.RefreshToken)
+	return nil
+}
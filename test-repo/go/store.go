@@ -0,0 +1,72 @@
+// Storage driver abstraction. UserRepository (and friends) used to be
+// hard-wired to an in-memory map; Store lets callers pick a backend by name
+// and configure it uniformly, so data survives a restart when a real driver
+// is selected.
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// Config selects and configures a storage driver.
+type Config struct {
+	// Driver is the registered driver name: "memory" or "mongo".
+	Driver string
+	// DSN is the driver-specific connection string (ignored by "memory").
+	DSN string
+	// Database is the database/schema name to use, where applicable.
+	Database string
+	// Logger receives operational log lines from the store and its repositories.
+	Logger Logger
+	// ChangeLogPath, if set, makes the store record its audit trail to that
+	// JSONL file instead of keeping it in memory.
+	ChangeLogPath string
+}
+
+// newChangeRepository builds the ChangeRepository a driver should use given
+// cfg, so every driver gets file-backed auditing for free when configured.
+func newChangeRepository(cfg Config) (ChangeRepository, error) {
+	if cfg.ChangeLogPath == "" {
+		return NewInMemoryChangeRepository(), nil
+	}
+	return NewFileChangeRepository(cfg.ChangeLogPath)
+}
+
+// ListFilter narrows List results so callers can page through large
+// collections instead of loading everything into memory.
+type ListFilter struct {
+	Limit  int
+	Offset int
+	Status Status
+}
+
+// Store is a handle to a configured storage backend.
+type Store interface {
+	// Users returns the user repository for this store.
+	Users() Repository
+	// Changes returns the audit trail repository for this store.
+	Changes() ChangeRepository
+	// Close releases any resources (connections, file handles) held by the store.
+	Close(ctx context.Context) error
+}
+
+// driverFactory constructs a Store from Config.
+type driverFactory func(ctx context.Context, cfg Config) (Store, error)
+
+var drivers = map[string]driverFactory{}
+
+// RegisterDriver makes a driver available under name for Open to find. It
+// is meant to be called from a driver's init(), mirroring database/sql.
+func RegisterDriver(name string, factory driverFactory) {
+	drivers[name] = factory
+}
+
+// Open builds a Store using the driver named in cfg.Driver.
+func Open(ctx context.Context, cfg Config) (Store, error) {
+	factory, ok := drivers[cfg.Driver]
+	if !ok {
+		return nil, fmt.Errorf("store: unknown driver %q", cfg.Driver)
+	}
+	return factory(ctx, cfg)
+}
@@ -0,0 +1,38 @@
+package main
+
+import "context"
+
+func init() {
+	RegisterDriver("memory", openMemoryStore)
+}
+
+// memoryStore is the zero-config Store backed by UserRepository's
+// in-memory repository.Repository. Users do not survive a restart; the
+// audit trail does only when cfg.ChangeLogPath makes it file-backed.
+type memoryStore struct {
+	users   *UserRepository
+	changes ChangeRepository
+}
+
+func openMemoryStore(ctx context.Context, cfg Config) (Store, error) {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = &SimpleLogger{}
+	}
+
+	changes, err := newChangeRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &memoryStore{
+		users:   NewUserRepository(logger, changes),
+		changes: changes,
+	}, nil
+}
+
+func (s *memoryStore) Users() Repository { return s.users }
+
+func (s *memoryStore) Changes() ChangeRepository { return s.changes }
+
+func (s *memoryStore) Close(ctx context.Context) error { return nil }
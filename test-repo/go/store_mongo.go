@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func init() {
+	RegisterDriver("mongo", openMongoStore)
+}
+
+const (
+	usersCollection    = "users"
+	countersCollection = "counters"
+)
+
+// mongoStore is a Store backed by a MongoDB database. It maps User to a
+// BSON document using the bson tags on User, which mirror its json tags.
+type mongoStore struct {
+	client  *mongo.Client
+	db      *mongo.Database
+	logger  Logger
+	changes ChangeRepository
+}
+
+func openMongoStore(ctx context.Context, cfg Config) (Store, error) {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.DSN))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	logger := cfg.Logger
+	if logger == nil {
+		logger = &SimpleLogger{}
+	}
+
+	changes, err := newChangeRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mongoStore{
+		client:  client,
+		db:      client.Database(cfg.Database),
+		logger:  logger,
+		changes: changes,
+	}, nil
+}
+
+func (s *mongoStore) Users() Repository {
+	return &mongoUserRepository{
+		collection: s.db.Collection(usersCollection),
+		counters:   s.db.Collection(countersCollection),
+		logger:     s.logger,
+		recorder:   s.changes,
+	}
+}
+
+func (s *mongoStore) Changes() ChangeRepository { return s.changes }
+
+func (s *mongoStore) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+// mongoUserRepository implements Repository against a MongoDB collection.
+type mongoUserRepository struct {
+	collection *mongo.Collection
+	counters   *mongo.Collection
+	logger     Logger
+	recorder   ChangeRepository // nil disables auditing
+}
+
+// nextID atomically reserves the next UserID by incrementing a counter
+// document keyed by usersCollection, mirroring UserRepository.nextID's
+// auto-increment behavior for backends that don't hand out IDs themselves.
+func (r *mongoUserRepository) nextID(ctx context.Context) (UserID, error) {
+	var counter struct {
+		Seq int64 `bson:"seq"`
+	}
+
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+	err := r.counters.FindOneAndUpdate(ctx,
+		bson.M{"_id": usersCollection},
+		bson.M{"$inc": bson.M{"seq": 1}},
+		opts,
+	).Decode(&counter)
+	if err != nil {
+		return 0, fmt.Errorf("mongo: reserve next user id: %w", err)
+	}
+	return UserID(counter.Seq), nil
+}
+
+func (r *mongoUserRepository) FindByID(ctx context.Context, id UserID) (*User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var user User
+	err := r.collection.FindOne(ctx, bson.M{"id": id}).Decode(&user)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *mongoUserRepository) FindByEmail(ctx context.Context, email string) (*User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var user User
+	err := r.collection.FindOne(ctx, bson.M{"email": email}).Decode(&user)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *mongoUserRepository) Save(ctx context.Context, user *User) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := Validate(user, userSaveRules(ctx, r, user.ID)...); err != nil {
+		return err
+	}
+
+	isNew := user.ID == 0
+
+	var before *User
+	if isNew {
+		id, err := r.nextID(ctx)
+		if err != nil {
+			return err
+		}
+		user.ID = id
+		user.CreatedAt = time.Now()
+	} else {
+		// Best-effort: if the lookup fails we still save, just without a diff.
+		before, _ = r.FindByID(ctx, user.ID)
+	}
+	user.UpdatedAt = time.Now()
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"id": user.ID}, user, opts)
+	if err != nil {
+		return err
+	}
+
+	op := ChangeUpdate
+	if isNew {
+		op = ChangeCreate
+	}
+	recordChange(ctx, r.recorder, r.logger, op, "User", user.ID, before, user)
+
+	r.logger.Info("User saved", "id", user.ID, "name", user.Name)
+	return nil
+}
+
+func (r *mongoUserRepository) Delete(ctx context.Context, id UserID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	before, _ := r.FindByID(ctx, id)
+
+	res, err := r.collection.DeleteOne(ctx, bson.M{"id": id})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+
+	recordChange(ctx, r.recorder, r.logger, ChangeDelete, "User", id, before, nil)
+
+	r.logger.Info("User deleted", "id", id)
+	return nil
+}
+
+func (r *mongoUserRepository) List(ctx context.Context, filter ListFilter) ([]*User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	query := bson.M{}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+
+	opts := options.Find()
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+	if filter.Offset > 0 {
+		opts.SetSkip(int64(filter.Offset))
+	}
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	users := make([]*User, 0)
+	for cursor.Next(ctx) {
+		var user User
+		if err := cursor.Decode(&user); err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+	return users, cursor.Err()
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationError aggregates field-level validation failures so callers can
+// report every problem at once instead of stopping at the first one.
+type ValidationError struct {
+	Fields map[string]string
+}
+
+func (e *ValidationError) Error() string {
+	keys := make([]string, 0, len(e.Fields))
+	for field := range e.Fields {
+		keys = append(keys, field)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, field := range keys {
+		parts = append(parts, fmt.Sprintf("%s: %s", field, e.Fields[field]))
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidInput, strings.Join(parts, "; "))
+}
+
+// Is lets errors.Is(err, ErrInvalidInput) succeed for a *ValidationError.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidInput
+}
+
+// FieldValidator checks one aspect of entity, returning the field it
+// checked and a non-empty message if the check failed.
+type FieldValidator[T any] func(entity T) (field, message string)
+
+// Validate runs every rule against entity and aggregates any failures into
+// a *ValidationError. It returns nil if every rule passes.
+func Validate[T any](entity T, rules ...FieldValidator[T]) error {
+	verr := &ValidationError{Fields: make(map[string]string)}
+	for _, rule := range rules {
+		if field, message := rule(entity); message != "" {
+			verr.Fields[field] = message
+		}
+	}
+	if len(verr.Fields) == 0 {
+		return nil
+	}
+	return verr
+}
+
+// RequiredString rejects an empty string returned by get.
+func RequiredString[T any](field string, get func(T) string) FieldValidator[T] {
+	return func(entity T) (string, string) {
+		if get(entity) == "" {
+			return field, "is required"
+		}
+		return field, ""
+	}
+}
+
+// MaxLen rejects a string returned by get that is longer than max.
+func MaxLen[T any](field string, max int, get func(T) string) FieldValidator[T] {
+	return func(entity T) (string, string) {
+		if utf8.RuneCountInString(get(entity)) > max {
+			return field, fmt.Sprintf("must be at most %d characters", max)
+		}
+		return field, ""
+	}
+}
+
+// OneOf rejects a value returned by get that isn't one of allowed.
+func OneOf[T any, V comparable](field string, get func(T) V, allowed ...V) FieldValidator[T] {
+	return func(entity T) (string, string) {
+		value := get(entity)
+		for _, candidate := range allowed {
+			if candidate == value {
+				return field, ""
+			}
+		}
+		return field, fmt.Sprintf("must be one of %v", allowed)
+	}
+}
+
+type mxLookupContextKey struct{}
+
+// WithMXLookup enables the optional MX-record check performed by Email
+// when ctx is passed through to it. It's opt-in because the lookup makes
+// a real DNS query, which is undesirable in most tests and hot paths.
+func WithMXLookup(ctx context.Context) context.Context {
+	return context.WithValue(ctx, mxLookupContextKey{}, true)
+}
+
+func mxLookupEnabled(ctx context.Context) bool {
+	enabled, _ := ctx.Value(mxLookupContextKey{}).(bool)
+	return enabled
+}
+
+// Email rejects a string returned by get that isn't RFC 5322 address. If
+// ctx has been passed through WithMXLookup, it additionally rejects
+// addresses whose domain has no MX record.
+func Email[T any](ctx context.Context, field string, get func(T) string) FieldValidator[T] {
+	return func(entity T) (string, string) {
+		addr, err := mail.ParseAddress(get(entity))
+		if err != nil {
+			return field, "must be a valid email address"
+		}
+
+		if mxLookupEnabled(ctx) {
+			domain := addr.Address[strings.LastIndex(addr.Address, "@")+1:]
+			if _, err := net.LookupMX(domain); err != nil {
+				return field, "domain has no mail exchanger"
+			}
+		}
+		return field, ""
+	}
+}
+
+// userSaveRules is the rule set every Repository implementation's Save
+// must run, so a user saved through any backend satisfies the same
+// invariants regardless of which driver stores it.
+func userSaveRules(ctx context.Context, repo Repository, id UserID) []FieldValidator[*User] {
+	return []FieldValidator[*User]{
+		RequiredString("name", func(u *User) string { return u.Name }),
+		MaxLen("name", 200, func(u *User) string { return u.Name }),
+		RequiredString("email", func(u *User) string { return u.Email }),
+		Email[*User](ctx, "email", func(u *User) string { return u.Email }),
+		Unique(ctx, "email", repo, id, func(u *User) string { return u.Email }),
+		OneOf("status", func(u *User) Status { return u.Status }, StatusActive, StatusInactive, StatusPending),
+	}
+}
+
+// Unique rejects a string returned by get that's already used by another
+// entity in repo. id identifies the entity being validated, so it can save
+// over itself without tripping the uniqueness check. It looks the value up
+// directly via repo.FindByEmail rather than paging through every entity, so
+// it stays cheap against a large, indexed backend.
+func Unique(ctx context.Context, field string, repo Repository, id UserID, get func(*User) string) FieldValidator[*User] {
+	return func(entity *User) (string, string) {
+		other, err := repo.FindByEmail(ctx, get(entity))
+		if err != nil {
+			// Not found (or a lookup error we can't act on) means no conflict.
+			return field, ""
+		}
+		if other.ID != id {
+			return field, "is already in use"
+		}
+		return field, ""
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestValidate_AggregatesAllFailures(t *testing.T) {
+	user := &User{Name: "", Email: "", Status: "bogus"}
+
+	err := Validate(user,
+		RequiredString("name", func(u *User) string { return u.Name }),
+		RequiredString("email", func(u *User) string { return u.Email }),
+		OneOf("status", func(u *User) Status { return u.Status }, StatusActive, StatusInactive),
+	)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("got %T, want *ValidationError", err)
+	}
+	if len(verr.Fields) != 3 {
+		t.Fatalf("got %d field errors, want 3: %v", len(verr.Fields), verr.Fields)
+	}
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Error("expected errors.Is(err, ErrInvalidInput) to hold")
+	}
+}
+
+func TestValidate_NoRulesFailingReturnsNil(t *testing.T) {
+	user := &User{Name: "Alice", Email: "alice@example.com", Status: StatusActive}
+
+	err := Validate(user,
+		RequiredString("name", func(u *User) string { return u.Name }),
+		OneOf("status", func(u *User) Status { return u.Status }, StatusActive),
+	)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+}
+
+func TestRequiredString(t *testing.T) {
+	get := func(u *User) string { return u.Name }
+
+	if _, msg := RequiredString[*User]("name", get)(&User{Name: ""}); msg == "" {
+		t.Error("expected a failure message for an empty string")
+	}
+	if _, msg := RequiredString[*User]("name", get)(&User{Name: "Alice"}); msg != "" {
+		t.Errorf("got message %q, want none", msg)
+	}
+}
+
+func TestMaxLen(t *testing.T) {
+	get := func(u *User) string { return u.Name }
+
+	if _, msg := MaxLen[*User]("name", 3, get)(&User{Name: "Alice"}); msg == "" {
+		t.Error("expected a failure message for a too-long string")
+	}
+	if _, msg := MaxLen[*User]("name", 10, get)(&User{Name: "Alice"}); msg != "" {
+		t.Errorf("got message %q, want none", msg)
+	}
+}
+
+func TestOneOf(t *testing.T) {
+	get := func(u *User) Status { return u.Status }
+
+	if _, msg := OneOf("status", get, StatusActive, StatusInactive)(&User{Status: "bogus"}); msg == "" {
+		t.Error("expected a failure message for a disallowed value")
+	}
+	if _, msg := OneOf("status", get, StatusActive, StatusInactive)(&User{Status: StatusActive}); msg != "" {
+		t.Errorf("got message %q, want none", msg)
+	}
+}
+
+func TestEmail(t *testing.T) {
+	get := func(u *User) string { return u.Email }
+	ctx := context.Background()
+
+	if _, msg := Email[*User](ctx, "email", get)(&User{Email: "not-an-email"}); msg == "" {
+		t.Error("expected a failure message for an invalid address")
+	}
+	if _, msg := Email[*User](ctx, "email", get)(&User{Email: "alice@example.com"}); msg != "" {
+		t.Errorf("got message %q, want none", msg)
+	}
+}
+
+func TestUnique(t *testing.T) {
+	ctx := context.Background()
+	repo := NewUserRepository(&SimpleLogger{}, nil)
+
+	existing := NewUser("Alice", "alice@example.com")
+	if err := repo.Save(ctx, existing); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	get := func(u *User) string { return u.Email }
+
+	// A different candidate reusing an existing email conflicts.
+	if _, msg := Unique(ctx, "email", repo, 0, get)(&User{ID: 0, Email: "alice@example.com"}); msg == "" {
+		t.Error("expected a conflict for a duplicate email")
+	}
+
+	// The existing user re-saving over itself does not conflict.
+	if _, msg := Unique(ctx, "email", repo, existing.ID, get)(&User{ID: existing.ID, Email: "alice@example.com"}); msg != "" {
+		t.Errorf("got message %q, want none for the same entity", msg)
+	}
+
+	// An email nobody uses never conflicts.
+	if _, msg := Unique(ctx, "email", repo, 0, get)(&User{ID: 0, Email: "bob@example.com"}); msg != "" {
+		t.Errorf("got message %q, want none for an unused email", msg)
+	}
+}